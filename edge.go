@@ -0,0 +1,55 @@
+package graphgo
+
+// Edge is a directed, labeled relationship between two nodes
+type Edge struct {
+	Key   string                 `json:"key"`
+	Label string                 `json:"label"`
+	Start string                 `json:"start"`
+	End   string                 `json:"end"`
+	Props map[string]interface{} `json:"props"`
+
+	// The cascade flags below apply when the edge is removed as part of
+	// DeleteNode/DeleteNodeCascade; DeleteEdge removes the edge alone and
+	// does not cascade
+	//
+	// CascadeToTarget, when true, deletes the end node (and transitively its
+	// own edges) whenever this edge is removed
+	CascadeToTarget bool `json:"cascadeToTarget"`
+	// CascadeLastToTarget deletes the end node only if, after this edge is
+	// removed, it has no other edge left sharing this edge's label
+	CascadeLastToTarget bool `json:"cascadeLastToTarget"`
+	// CascadeFromTarget, when true, deletes the start node (and transitively
+	// its own edges) whenever this edge is removed
+	CascadeFromTarget bool `json:"cascadeFromTarget"`
+	// CascadeLastFromTarget deletes the start node only if, after this edge
+	// is removed, it has no other edge left sharing this edge's label
+	CascadeLastFromTarget bool `json:"cascadeLastFromTarget"`
+}
+
+// NewEdge instanciates
+func NewEdge(key, label, start, end string, props map[string]interface{}) *Edge {
+	if props == nil {
+		props = map[string]interface{}{}
+	}
+	return &Edge{
+		Key:   key,
+		Label: label,
+		Start: start,
+		End:   end,
+		Props: props,
+	}
+}
+
+// Get finds a property by key
+func (edge *Edge) Get(key string) (interface{}, error) {
+	value, ok := edge.Props[key]
+	if !ok {
+		return nil, errPropNotFound(key)
+	}
+	return value, nil
+}
+
+// SetProperty sets or overwrites a property
+func (edge *Edge) SetProperty(key string, value interface{}) {
+	edge.Props[key] = value
+}
@@ -0,0 +1,24 @@
+package graphgo
+
+import "fmt"
+
+// errNodeNotFound builds the error returned when a node key is unknown to the graph
+func errNodeNotFound(key string) error {
+	return fmt.Errorf("node not found: %q", key)
+}
+
+// errEdgeNotFound builds the error returned when an edge key is unknown to the graph
+func errEdgeNotFound(key string) error {
+	return fmt.Errorf("edge not found: %q", key)
+}
+
+// errPropNotFound builds the error returned when a property key is missing from a props map
+func errPropNotFound(key string) error {
+	return fmt.Errorf("property not found: %q", key)
+}
+
+// errCycleDetected builds the error returned when a topological sort can't
+// consume every node, listing the node keys that form the remaining cycle
+func errCycleDetected(keys []string) error {
+	return fmt.Errorf("graph contains a cycle among nodes: %v", keys)
+}
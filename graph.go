@@ -7,6 +7,15 @@ type Graph struct {
 	Nodes       map[string]*Node `json:"nodes"`
 	Edges       map[string]*Edge `json:"edges"`
 	LegacyIndex *LegacyIndex     `json:"legacyIndex"`
+
+	// queries tracks every Query built on top of this graph so their result
+	// sets can be pruned whenever the graph mutates. Go has no weak
+	// references, so entries accumulate for the lifetime of the graph even
+	// after a caller drops the last reference to the *Query itself; long-
+	// lived graphs that build many queries (e.g. in a loop, or via Deepen,
+	// which allocates one per result node) should call ForgetQueries
+	// periodically once they no longer need older queries kept in sync
+	queries []*Query
 }
 
 // NewEmptyGraph instanciates
@@ -146,10 +155,119 @@ func (graph *Graph) MergeEdge(edgeKey, label string, start, end string, props ma
 	return edge, nil
 }
 
-// DeleteNode
+// DeleteNode removes a node and every edge incident to it, cascading the
+// deletion to neighbouring nodes according to each removed edge's cascade flags
 func (graph *Graph) DeleteNode(nodeKey string) error {
+	_, err := graph.DeleteNodeCascade(nodeKey)
+	return err
+}
+
+// DeleteNodeCascade behaves like DeleteNode but returns the key of every node
+// that ended up removed, in the order the cascade reached them, so callers can
+// log or undo the operation
+func (graph *Graph) DeleteNodeCascade(nodeKey string) ([]string, error) {
+	if !graph.HasNode(nodeKey) {
+		return nil, errNodeNotFound(nodeKey)
+	}
+
+	removed := map[string]bool{}
+	order := []string{}
+	graph.deleteNodeRec(nodeKey, removed, &order)
+	graph.invalidateQueries()
+
+	return order, nil
+}
+
+// deleteNodeRec removes nodeKey and walks its incident edges, applying their
+// cascade semantics. removed guards against revisiting a node that cascading
+// edges already pulled in, which also makes this safe on cyclic graphs
+func (graph *Graph) deleteNodeRec(nodeKey string, removed map[string]bool, order *[]string) {
+	if removed[nodeKey] {
+		return
+	}
+
+	node, err := graph.getNode(nodeKey)
+	if err != nil {
+		return
+	}
+
+	removed[nodeKey] = true
+	*order = append(*order, nodeKey)
+
+	// Snapshot the incident edge keys upfront: deleteEdgeRec mutates
+	// node.Out/node.In as it goes
+	outEdges := make([]string, 0, len(node.Out))
+	for edgeKey := range node.Out {
+		outEdges = append(outEdges, edgeKey)
+	}
+	inEdges := make([]string, 0, len(node.In))
+	for edgeKey := range node.In {
+		inEdges = append(inEdges, edgeKey)
+	}
+
+	for _, edgeKey := range outEdges {
+		graph.deleteEdgeRec(edgeKey, removed, order)
+	}
+	for _, edgeKey := range inEdges {
+		graph.deleteEdgeRec(edgeKey, removed, order)
+	}
+
 	delete(graph.Nodes, nodeKey)
-	return nil
+}
+
+// deleteEdgeRec removes a single edge, detaches it from both endpoints, and -
+// if the edge's cascade flags demand it - recursively deletes the opposite endpoint
+func (graph *Graph) deleteEdgeRec(edgeKey string, removed map[string]bool, order *[]string) {
+	edge, err := graph.getEdge(edgeKey)
+	if err != nil {
+		return
+	}
+
+	startNode, startErr := graph.getNode(edge.Start)
+	endNode, endErr := graph.getNode(edge.End)
+
+	delete(graph.Edges, edgeKey)
+	if startErr == nil {
+		delete(startNode.Out, edgeKey)
+	}
+	if endErr == nil {
+		delete(endNode.In, edgeKey)
+	}
+
+	if endErr == nil && !removed[endNode.Key] {
+		if edge.CascadeToTarget || (edge.CascadeLastToTarget && !endNode.hasEdgeWithLabel(edge.Label)) {
+			graph.deleteNodeRec(endNode.Key, removed, order)
+		}
+	}
+
+	if startErr == nil && !removed[startNode.Key] {
+		if edge.CascadeFromTarget || (edge.CascadeLastFromTarget && !startNode.hasEdgeWithLabel(edge.Label)) {
+			graph.deleteNodeRec(startNode.Key, removed, order)
+		}
+	}
+}
+
+// registerQuery tracks a query built on top of this graph so its result set
+// can be pruned whenever the graph mutates
+func (graph *Graph) registerQuery(q *Query) {
+	graph.queries = append(graph.queries, q)
+}
+
+// invalidateQueries drops any node that no longer exists in the graph from
+// every query result still being tracked
+func (graph *Graph) invalidateQueries() {
+	for _, q := range graph.queries {
+		q.prune()
+	}
+}
+
+// ForgetQueries releases every *Query this graph is tracking for automatic
+// invalidation. Queries forgotten this way keep working, but stop being kept
+// in sync with future DeleteNode/DeleteEdge calls: call it once you're done
+// with a batch of queries (e.g. at the end of a request, or periodically in
+// a long-running process) to bound the registry's memory
+func (graph *Graph) ForgetQueries() {
+	graph.queries = nil
 }
 
 // DeleteEdge
@@ -174,5 +292,6 @@ func (graph *Graph) DeleteEdge(edgeKey string) error {
 
 	// Delete the edge
 	delete(graph.Edges, edgeKey)
+	graph.invalidateQueries()
 	return nil
 }
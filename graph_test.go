@@ -0,0 +1,101 @@
+package graphgo
+
+import "testing"
+
+func TestDeleteNodeCascadesToTarget(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("parent", nil)
+	g.MergeNode("child", nil)
+	edge, err := g.MergeEdge("e1", "owns", "parent", "child", nil)
+	if err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	edge.CascadeToTarget = true
+
+	removed, err := g.DeleteNodeCascade("parent")
+	if err != nil {
+		t.Fatalf("DeleteNodeCascade: %v", err)
+	}
+	if g.HasNode("parent") || g.HasNode("child") {
+		t.Fatalf("expected both parent and child to be removed, got %v", removed)
+	}
+	if len(removed) != 2 {
+		t.Fatalf("expected 2 removed keys, got %v", removed)
+	}
+}
+
+func TestDeleteNodeCascadeLastOnlyWhenNoEdgesRemain(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("tag", nil)
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+
+	e1, err := g.MergeEdge("e1", "tagged", "a", "tag", nil)
+	if err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	e1.CascadeLastToTarget = true
+
+	e2, err := g.MergeEdge("e2", "tagged", "b", "tag", nil)
+	if err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	e2.CascadeLastToTarget = true
+
+	if err := g.DeleteNode("a"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if !g.HasNode("tag") {
+		t.Fatalf("expected tag to survive while b still references it")
+	}
+
+	if err := g.DeleteNode("b"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if g.HasNode("tag") {
+		t.Fatalf("expected tag to be cascaded away once its last tagged edge is gone")
+	}
+}
+
+// Regression-style test: deleteNodeRec must guard against revisiting a node
+// that cascading edges already pulled in, or a cyclic CascadeToTarget chain
+// would recurse forever
+func TestDeleteNodeCascadeHandlesCycles(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+
+	e1, err := g.MergeEdge("e1", "ref", "a", "b", nil)
+	if err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	e1.CascadeToTarget = true
+
+	e2, err := g.MergeEdge("e2", "ref", "b", "a", nil)
+	if err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	e2.CascadeToTarget = true
+
+	if err := g.DeleteNode("a"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if g.HasNode("a") || g.HasNode("b") {
+		t.Fatalf("expected both cyclic nodes to be removed without infinite recursion")
+	}
+}
+
+func TestForgetQueriesDropsRegistry(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+
+	NewQuery(g, "a")
+	if len(g.queries) == 0 {
+		t.Fatalf("expected NewQuery to register itself with the graph")
+	}
+
+	g.ForgetQueries()
+	if len(g.queries) != 0 {
+		t.Fatalf("expected ForgetQueries to clear the registry")
+	}
+}
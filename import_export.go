@@ -0,0 +1,319 @@
+package graphgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Partition identifies a named subset of a graph's nodes, either by the
+// value of a node property (Property) or by an arbitrary predicate (Match).
+// If neither is set, every node matches
+type Partition struct {
+	Name     string
+	Property string
+	Match    func(*Node) bool
+}
+
+// includes reports whether node belongs to this partition
+func (p Partition) includes(node *Node) bool {
+	if p.Match != nil {
+		return p.Match(node)
+	}
+	if p.Property == "" {
+		return true
+	}
+	value, err := node.Get(p.Property)
+	if err != nil {
+		return false
+	}
+	return fmt.Sprintf("%v", value) == p.Name
+}
+
+// ExportOptions controls what Export writes
+type ExportOptions struct {
+	// Partition, if set, restricts the export to nodes matching it, and to
+	// edges whose start and end both belong to it
+	Partition *Partition
+}
+
+// OnConflict controls how Import reconciles a record against an existing
+// node or edge with the same key
+type OnConflict int
+
+const (
+	// Skip leaves the existing node/edge untouched
+	Skip OnConflict = iota
+	// Overwrite replaces the existing props (and edge cascade flags) wholesale
+	Overwrite
+	// MergeProps merges the incoming props into the existing ones, keeping
+	// properties the incoming record doesn't mention
+	MergeProps
+)
+
+// ImportOptions controls how Import reconciles incoming records
+type ImportOptions struct {
+	OnConflict OnConflict
+}
+
+// nodeRecord is the on-the-wire shape of a node inside an exported document
+type nodeRecord struct {
+	Key   string                 `json:"key"`
+	Props map[string]interface{} `json:"props"`
+}
+
+// edgeRecord is the on-the-wire shape of an edge inside an exported document
+type edgeRecord struct {
+	Key   string                 `json:"key"`
+	Label string                 `json:"label"`
+	Start string                 `json:"start"`
+	End   string                 `json:"end"`
+	Props map[string]interface{} `json:"props"`
+
+	CascadeToTarget       bool `json:"cascadeToTarget,omitempty"`
+	CascadeLastToTarget   bool `json:"cascadeLastToTarget,omitempty"`
+	CascadeFromTarget     bool `json:"cascadeFromTarget,omitempty"`
+	CascadeLastFromTarget bool `json:"cascadeLastFromTarget,omitempty"`
+}
+
+// Export writes the graph to out as a streaming JSON document
+// {"nodes":[...], "edges":[...]}, optionally restricted to opts.Partition
+func (graph *Graph) Export(out io.Writer, opts ExportOptions) error {
+	enc := json.NewEncoder(out)
+
+	if _, err := io.WriteString(out, `{"nodes":[`); err != nil {
+		return err
+	}
+
+	included := map[string]bool{}
+	first := true
+	for key, node := range graph.Nodes {
+		if opts.Partition != nil && !opts.Partition.includes(node) {
+			continue
+		}
+		included[key] = true
+
+		if !first {
+			if _, err := io.WriteString(out, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		if err := enc.Encode(nodeRecord{Key: node.Key, Props: node.Props}); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(out, `],"edges":[`); err != nil {
+		return err
+	}
+
+	first = true
+	for _, edge := range graph.Edges {
+		if opts.Partition != nil && (!included[edge.Start] || !included[edge.End]) {
+			continue
+		}
+
+		if !first {
+			if _, err := io.WriteString(out, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		record := edgeRecord{
+			Key: edge.Key, Label: edge.Label, Start: edge.Start, End: edge.End, Props: edge.Props,
+			CascadeToTarget:       edge.CascadeToTarget,
+			CascadeLastToTarget:   edge.CascadeLastToTarget,
+			CascadeFromTarget:     edge.CascadeFromTarget,
+			CascadeLastFromTarget: edge.CascadeLastFromTarget,
+		}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(out, "]}")
+	return err
+}
+
+// Import reads a document written by Export and merges its nodes and edges
+// into the graph via MergeNode/MergeEdge, reconciling conflicts per
+// opts.OnConflict. It reads incrementally via json.Decoder.Token/Decode so a
+// large document never has to fit in memory all at once
+func (graph *Graph) Import(in io.Reader, opts ImportOptions) error {
+	dec := json.NewDecoder(in)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		field, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("graphgo: expected a field name, got %v", tok)
+		}
+
+		switch field {
+		case "nodes":
+			if err := graph.importNodes(dec, opts); err != nil {
+				return err
+			}
+		case "edges":
+			if err := graph.importEdges(dec, opts); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("graphgo: unknown import field %q", field)
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func (graph *Graph) importNodes(dec *json.Decoder, opts ImportOptions) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		var rec nodeRecord
+		if err := dec.Decode(&rec); err != nil {
+			return err
+		}
+		graph.mergeImportedNode(rec, opts)
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func (graph *Graph) importEdges(dec *json.Decoder, opts ImportOptions) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		var rec edgeRecord
+		if err := dec.Decode(&rec); err != nil {
+			return err
+		}
+		if err := graph.mergeImportedEdge(rec, opts); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func (graph *Graph) mergeImportedNode(rec nodeRecord, opts ImportOptions) {
+	existing, err := graph.getNode(rec.Key)
+	if err != nil {
+		graph.MergeNode(rec.Key, rec.Props)
+		return
+	}
+
+	switch opts.OnConflict {
+	case Skip:
+	case Overwrite:
+		existing.Props = map[string]interface{}{}
+		for k, v := range rec.Props {
+			existing.Props[k] = v
+		}
+	case MergeProps:
+		graph.MergeNode(rec.Key, rec.Props)
+	}
+}
+
+func (graph *Graph) mergeImportedEdge(rec edgeRecord, opts ImportOptions) error {
+	existing, err := graph.getEdge(rec.Key)
+	if err != nil {
+		edge, err := graph.MergeEdge(rec.Key, rec.Label, rec.Start, rec.End, rec.Props)
+		if err != nil {
+			return err
+		}
+		edge.CascadeToTarget = rec.CascadeToTarget
+		edge.CascadeLastToTarget = rec.CascadeLastToTarget
+		edge.CascadeFromTarget = rec.CascadeFromTarget
+		edge.CascadeLastFromTarget = rec.CascadeLastFromTarget
+		return nil
+	}
+
+	switch opts.OnConflict {
+	case Skip:
+	case Overwrite:
+		existing.Props = map[string]interface{}{}
+		for k, v := range rec.Props {
+			existing.Props[k] = v
+		}
+		existing.CascadeToTarget = rec.CascadeToTarget
+		existing.CascadeLastToTarget = rec.CascadeLastToTarget
+		existing.CascadeFromTarget = rec.CascadeFromTarget
+		existing.CascadeLastFromTarget = rec.CascadeLastFromTarget
+	case MergeProps:
+		for k, v := range rec.Props {
+			existing.SetProperty(k, v)
+		}
+	}
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != want {
+		return fmt.Errorf("graphgo: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// ExportPartitions writes one JSON document per partition, via the writer
+// open(partition) returns, so a graph can be sharded across processes
+func (graph *Graph) ExportPartitions(partitions []Partition, open func(Partition) (io.WriteCloser, error)) error {
+	for _, partition := range partitions {
+		w, err := open(partition)
+		if err != nil {
+			return err
+		}
+
+		err = graph.Export(w, ExportOptions{Partition: &partition})
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportPartitions reads and merges every reader open(partition) returns, in
+// order, so a graph previously sharded across partitions can be reassembled
+func (graph *Graph) ImportPartitions(partitions []Partition, open func(Partition) (io.ReadCloser, error), opts ImportOptions) error {
+	for _, partition := range partitions {
+		r, err := open(partition)
+		if err != nil {
+			return err
+		}
+
+		err = graph.Import(r, opts)
+		if closeErr := r.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
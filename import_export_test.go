@@ -0,0 +1,85 @@
+package graphgo
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestImportExportRoundTrip(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("alice", map[string]interface{}{"name": "Alice", "partition": "people"})
+	g.MergeNode("bob", map[string]interface{}{"name": "Bob", "partition": "people"})
+	edge, err := g.MergeEdge("alice-knows-bob", "knows", "alice", "bob", map[string]interface{}{"since": "2020"})
+	if err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	edge.CascadeToTarget = true
+
+	var buf bytes.Buffer
+	if err := g.Export(&buf, ExportOptions{}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	restored := NewEmptyGraph()
+	if err := restored.Import(&buf, ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if len(restored.Nodes) != len(g.Nodes) {
+		t.Fatalf("got %d nodes, want %d", len(restored.Nodes), len(g.Nodes))
+	}
+	for key, node := range g.Nodes {
+		restoredNode, err := restored.getNode(key)
+		if err != nil {
+			t.Fatalf("missing node %q after round trip", key)
+		}
+		if !reflect.DeepEqual(restoredNode.Props, node.Props) {
+			t.Errorf("node %q props = %v, want %v", key, restoredNode.Props, node.Props)
+		}
+	}
+
+	if len(restored.Edges) != len(g.Edges) {
+		t.Fatalf("got %d edges, want %d", len(restored.Edges), len(g.Edges))
+	}
+	restoredEdge, err := restored.getEdge(edge.Key)
+	if err != nil {
+		t.Fatalf("missing edge %q after round trip", edge.Key)
+	}
+	if restoredEdge.Start != edge.Start || restoredEdge.End != edge.End || restoredEdge.Label != edge.Label {
+		t.Errorf("restored edge = %+v, want %+v", restoredEdge, edge)
+	}
+	if !restoredEdge.CascadeToTarget {
+		t.Errorf("restored edge lost its CascadeToTarget flag")
+	}
+}
+
+func TestExportPartitionFilter(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("alice", map[string]interface{}{"partition": "people"})
+	g.MergeNode("widget", map[string]interface{}{"partition": "things"})
+	if _, err := g.MergeEdge("alice-owns-widget", "owns", "alice", "widget", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	var buf bytes.Buffer
+	partition := Partition{Name: "people", Property: "partition"}
+	if err := g.Export(&buf, ExportOptions{Partition: &partition}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	restored := NewEmptyGraph()
+	if err := restored.Import(&buf, ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if !restored.HasNode("alice") {
+		t.Errorf("expected partitioned export to include alice")
+	}
+	if restored.HasNode("widget") {
+		t.Errorf("expected partitioned export to exclude widget")
+	}
+	if restored.HasEdge("alice-owns-widget") {
+		t.Errorf("expected partitioned export to drop the cross-partition edge")
+	}
+}
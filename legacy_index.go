@@ -0,0 +1,14 @@
+package graphgo
+
+// LegacyIndex retains lookup structures kept around for backward compatibility
+// with graphs serialized by older versions of this package
+type LegacyIndex struct {
+	ByLabel map[string][]string `json:"byLabel"`
+}
+
+// NewLegacyIndex instanciates
+func NewLegacyIndex() *LegacyIndex {
+	return &LegacyIndex{
+		ByLabel: map[string][]string{},
+	}
+}
@@ -0,0 +1,83 @@
+package graphgo
+
+// Node is a vertex in the graph: a key, an arbitrary property bag, and the
+// keys of its incident edges, each mapped to its label
+type Node struct {
+	Key   string                 `json:"key"`
+	Props map[string]interface{} `json:"props"`
+
+	// Out maps outgoing edge keys to their label
+	Out map[string]string `json:"out"`
+	// In maps incoming edge keys to their label
+	In map[string]string `json:"in"`
+}
+
+// NewNode instanciates
+func NewNode(key string, props map[string]interface{}) *Node {
+	if props == nil {
+		props = map[string]interface{}{}
+	}
+	return &Node{
+		Key:   key,
+		Props: props,
+		Out:   map[string]string{},
+		In:    map[string]string{},
+	}
+}
+
+// Get finds a property by key
+func (node *Node) Get(key string) (interface{}, error) {
+	value, ok := node.Props[key]
+	if !ok {
+		return nil, errPropNotFound(key)
+	}
+	return value, nil
+}
+
+// SetProperty sets or overwrites a property
+func (node *Node) SetProperty(key string, value interface{}) {
+	node.Props[key] = value
+}
+
+// AddOutEdge registers an outgoing edge on this node
+func (node *Node) AddOutEdge(edgeKey, label string) {
+	node.Out[edgeKey] = label
+}
+
+// AddInEdge registers an incoming edge on this node
+func (node *Node) AddInEdge(edgeKey, label string) {
+	node.In[edgeKey] = label
+}
+
+// hasEdgeWithLabel reports whether the node still has an in or out edge
+// carrying the given label, used by "last" cascade semantics on delete
+func (node *Node) hasEdgeWithLabel(label string) bool {
+	for _, l := range node.Out {
+		if l == label {
+			return true
+		}
+	}
+	for _, l := range node.In {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy returns a shallow copy of the node, detached from the graph it came from
+func (node *Node) Copy() *Node {
+	props := map[string]interface{}{}
+	for k, v := range node.Props {
+		props[k] = v
+	}
+	out := map[string]string{}
+	for k, v := range node.Out {
+		out[k] = v
+	}
+	in := map[string]string{}
+	for k, v := range node.In {
+		in[k] = v
+	}
+	return &Node{Key: node.Key, Props: props, Out: out, In: in}
+}
@@ -0,0 +1,316 @@
+package graphgo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortDir selects the direction OrderBy sorts in
+type SortDir int
+
+const (
+	// Asc sorts ascending
+	Asc SortDir = iota
+	// Desc sorts descending
+	Desc
+)
+
+// OrderBy materializes an internal sorted slice of the current result's node
+// keys, ordered by property key with type-aware comparison (numeric kinds,
+// strings, and time.Time, via reflection), falling back to the node key
+// itself to keep the order stable. Paginate/PaginateLast walk this slice
+func (q *Query) OrderBy(key string, dir SortDir) *Query {
+	// Deep Calls
+	if q.IsDeep() {
+		for _, nestedQuery := range q.Queries {
+			nestedQuery.OrderBy(key, dir)
+		}
+		return q
+	}
+
+	keys := make([]string, 0, len(q.result))
+	for k := range q.result {
+		keys = append(keys, k)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		c := compareProp(q.result[keys[i]], q.result[keys[j]], key)
+		if dir == Desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	q.orderKey = key
+	q.orderDir = dir
+	q.ordered = keys
+	return q
+}
+
+// compareProp orders a and b by their key property, falling back to their
+// node key as a stable tiebreaker when the property is absent or equal
+func compareProp(a, b *Node, key string) int {
+	av, aok := a.Props[key]
+	bv, bok := b.Props[key]
+
+	switch {
+	case aok && bok:
+		if c := compareValues(av, bv); c != 0 {
+			return c
+		}
+	case aok && !bok:
+		return -1
+	case !aok && bok:
+		return 1
+	}
+
+	return strings.Compare(a.Key, b.Key)
+}
+
+// compareValues type-switches a and b to compare them as time.Time, strings,
+// or numbers (via reflection); incomparable or mismatched types compare equal
+func compareValues(a, b interface{}) int {
+	if at, ok := asTime(a); ok {
+		if bt, ok := asTime(b); ok {
+			switch {
+			case at.Before(bt):
+				return -1
+			case at.After(bt):
+				return 1
+			}
+			return 0
+		}
+	}
+
+	if as, ok := a.(string); ok {
+		if bs, ok := b.(string); ok {
+			return strings.Compare(as, bs)
+		}
+	}
+
+	if af, aok := toFloat(a); aok {
+		if bf, bok := toFloat(b); bok {
+			switch {
+			case af < bf:
+				return -1
+			case af > bf:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	return 0
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func asTime(v interface{}) (time.Time, bool) {
+	if t, ok := v.(time.Time); ok {
+		return t, true
+	}
+	if s, ok := v.(string); ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// cursorPayload is the decoded shape of a pagination cursor: the ordering
+// value the node had when the cursor was issued, plus its key as a tiebreaker
+type cursorPayload struct {
+	OrderKey string      `json:"orderKey"`
+	Value    interface{} `json:"value"`
+	NodeKey  string      `json:"nodeKey"`
+}
+
+// encodeCursor builds the opaque, base64-encoded cursor for nodeKey at its
+// current position in q.ordered
+func (q *Query) encodeCursor(nodeKey string) string {
+	node := q.result[nodeKey]
+	value, _ := node.Get(q.orderKey)
+
+	raw, _ := json.Marshal(cursorPayload{OrderKey: q.orderKey, Value: value, NodeKey: nodeKey})
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(cursor string) (cursorPayload, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return cursorPayload{}, fmt.Errorf("graphgo: invalid cursor: %w", err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return cursorPayload{}, fmt.Errorf("graphgo: invalid cursor: %w", err)
+	}
+	return payload, nil
+}
+
+// cursorIndex locates cursor's position in q.ordered by binary search on its
+// stored ordering value rather than a stored index, so it stays correct even
+// if nodes were inserted or removed since the cursor was issued
+func (q *Query) cursorIndex(cursor string) (int, error) {
+	payload, err := decodeCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+	if payload.OrderKey != q.orderKey {
+		return 0, fmt.Errorf("graphgo: cursor was issued for a different OrderBy key")
+	}
+
+	idx := sort.Search(len(q.ordered), func(i int) bool {
+		node := q.result[q.ordered[i]]
+		c := compareValues(mustGet(node, q.orderKey), payload.Value)
+		if c == 0 {
+			c = strings.Compare(node.Key, payload.NodeKey)
+		}
+		if q.orderDir == Desc {
+			return c <= 0
+		}
+		return c >= 0
+	})
+
+	if idx >= len(q.ordered) || q.ordered[idx] != payload.NodeKey {
+		return 0, fmt.Errorf("graphgo: cursor %q no longer matches the current result set", cursor)
+	}
+
+	return idx, nil
+}
+
+func mustGet(node *Node, key string) interface{} {
+	value, _ := node.Get(key)
+	return value
+}
+
+// PageEdge pairs a node with the cursor pointing at its position
+type PageEdge struct {
+	Node   *Node
+	Cursor string
+}
+
+// PageInfo describes the boundaries of a Page, Relay-style
+type PageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     string
+	EndCursor       string
+}
+
+// Page is a single window of an ordered, paginated query result. It embeds
+// its own subset of nodes without ever mutating the Query.result it came
+// from, so Query stays reusable for further pagination
+type Page struct {
+	Edges    []PageEdge
+	PageInfo PageInfo
+
+	graph *Graph
+}
+
+// Query builds a fresh Query over exactly the nodes in this page, so
+// pagination composes with subsequent .Out()/.In() calls
+func (p *Page) Query() *Query {
+	q := NewEmptyQuery()
+	q.Graph = p.graph
+	for _, edge := range p.Edges {
+		q.result[edge.Node.Key] = edge.Node
+	}
+	if p.graph != nil {
+		p.graph.registerQuery(q)
+	}
+	return q
+}
+
+// Paginate returns the first nodes nodes after cursor after (empty to start
+// from the beginning), in the order established by the most recent OrderBy
+// (or node key order, if OrderBy was never called)
+func (q *Query) Paginate(first int, after string) (*Page, error) {
+	if q.ordered == nil {
+		q.OrderBy(q.orderKey, q.orderDir)
+	}
+
+	start := 0
+	if after != "" {
+		idx, err := q.cursorIndex(after)
+		if err != nil {
+			return nil, err
+		}
+		start = idx + 1
+	}
+
+	end := start + first
+	if end > len(q.ordered) {
+		end = len(q.ordered)
+	}
+	if start > len(q.ordered) {
+		start = len(q.ordered)
+	}
+
+	page := q.buildPage(start, end)
+	page.PageInfo.HasPreviousPage = start > 0
+	page.PageInfo.HasNextPage = end < len(q.ordered)
+	return page, nil
+}
+
+// PaginateLast returns the last nodes nodes before cursor before (empty to
+// end at the last node), in the order established by the most recent
+// OrderBy (or node key order, if OrderBy was never called)
+func (q *Query) PaginateLast(last int, before string) (*Page, error) {
+	if q.ordered == nil {
+		q.OrderBy(q.orderKey, q.orderDir)
+	}
+
+	end := len(q.ordered)
+	if before != "" {
+		idx, err := q.cursorIndex(before)
+		if err != nil {
+			return nil, err
+		}
+		end = idx
+	}
+
+	start := end - last
+	if start < 0 {
+		start = 0
+	}
+
+	page := q.buildPage(start, end)
+	page.PageInfo.HasPreviousPage = start > 0
+	page.PageInfo.HasNextPage = end < len(q.ordered)
+	return page, nil
+}
+
+func (q *Query) buildPage(start, end int) *Page {
+	edges := make([]PageEdge, 0, end-start)
+	for i := start; i < end; i++ {
+		nodeKey := q.ordered[i]
+		edges = append(edges, PageEdge{
+			Node:   q.result[nodeKey],
+			Cursor: q.encodeCursor(nodeKey),
+		})
+	}
+
+	page := &Page{Edges: edges, graph: q.Graph}
+	if len(edges) > 0 {
+		page.PageInfo.StartCursor = edges[0].Cursor
+		page.PageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	return page
+}
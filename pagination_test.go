@@ -0,0 +1,152 @@
+package graphgo
+
+import "testing"
+
+func TestOrderByThenPaginate(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", map[string]interface{}{"rank": 1.0})
+	g.MergeNode("b", map[string]interface{}{"rank": 2.0})
+	g.MergeNode("c", map[string]interface{}{"rank": 3.0})
+
+	q := NewQuery(g, "a", "b", "c").OrderBy("rank", Asc)
+
+	page, err := q.Paginate(2, "")
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if len(page.Edges) != 2 {
+		t.Fatalf("got %d edges, want 2", len(page.Edges))
+	}
+	if page.Edges[0].Node.Key != "a" || page.Edges[1].Node.Key != "b" {
+		t.Fatalf("unexpected page order: %+v", page.Edges)
+	}
+	if !page.PageInfo.HasNextPage {
+		t.Fatalf("expected HasNextPage to be true")
+	}
+}
+
+// Regression test: prune() must also drop stale keys from q.ordered, or
+// Paginate/buildPage panic dereferencing a node the graph already deleted
+func TestPaginateAfterDeleteDoesNotPanic(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", map[string]interface{}{"rank": 1.0})
+	g.MergeNode("b", map[string]interface{}{"rank": 2.0})
+	g.MergeNode("c", map[string]interface{}{"rank": 3.0})
+
+	q := NewQuery(g, "a", "b", "c").OrderBy("rank", Asc)
+
+	if err := g.DeleteNode("b"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+
+	page, err := q.Paginate(10, "")
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if len(page.Edges) != 2 {
+		t.Fatalf("got %d edges, want 2 (deleted node should be dropped)", len(page.Edges))
+	}
+	for _, edge := range page.Edges {
+		if edge.Node.Key == "b" {
+			t.Fatalf("deleted node %q leaked into the page", edge.Node.Key)
+		}
+	}
+}
+
+func buildRankedGraph(t *testing.T, n int) *Graph {
+	t.Helper()
+	g := NewEmptyGraph()
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+	for i := 0; i < n; i++ {
+		g.MergeNode(keys[i], map[string]interface{}{"rank": float64(i + 1)})
+	}
+	return g
+}
+
+func TestPaginateForwardByCursor(t *testing.T) {
+	g := buildRankedGraph(t, 4)
+	q := NewQuery(g, "a", "b", "c", "d").OrderBy("rank", Asc)
+
+	page1, err := q.Paginate(2, "")
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if len(page1.Edges) != 2 || page1.Edges[0].Node.Key != "a" || page1.Edges[1].Node.Key != "b" {
+		t.Fatalf("unexpected first page: %+v", page1.Edges)
+	}
+
+	page2, err := q.Paginate(2, page1.PageInfo.EndCursor)
+	if err != nil {
+		t.Fatalf("Paginate after cursor: %v", err)
+	}
+	if len(page2.Edges) != 2 || page2.Edges[0].Node.Key != "c" || page2.Edges[1].Node.Key != "d" {
+		t.Fatalf("unexpected second page: %+v", page2.Edges)
+	}
+	if page2.PageInfo.HasNextPage {
+		t.Fatalf("expected no next page after the last node")
+	}
+	if !page2.PageInfo.HasPreviousPage {
+		t.Fatalf("expected a previous page once past the first page")
+	}
+}
+
+func TestPaginateLastByCursorMatchesForwardPages(t *testing.T) {
+	g := buildRankedGraph(t, 4)
+	q := NewQuery(g, "a", "b", "c", "d").OrderBy("rank", Asc)
+
+	page1, err := q.Paginate(2, "")
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	page2, err := q.Paginate(2, page1.PageInfo.EndCursor)
+	if err != nil {
+		t.Fatalf("Paginate after cursor: %v", err)
+	}
+
+	last, err := q.PaginateLast(2, "")
+	if err != nil {
+		t.Fatalf("PaginateLast: %v", err)
+	}
+	if len(last.Edges) != 2 || last.Edges[0].Node.Key != page2.Edges[0].Node.Key || last.Edges[1].Node.Key != page2.Edges[1].Node.Key {
+		t.Fatalf("expected PaginateLast(2, \"\") to match the last forward page, got %+v", last.Edges)
+	}
+
+	previous, err := q.PaginateLast(2, last.PageInfo.StartCursor)
+	if err != nil {
+		t.Fatalf("PaginateLast before cursor: %v", err)
+	}
+	if len(previous.Edges) != 2 || previous.Edges[0].Node.Key != page1.Edges[0].Node.Key || previous.Edges[1].Node.Key != page1.Edges[1].Node.Key {
+		t.Fatalf("expected PaginateLast(2, cursor) to step back to the first forward page, got %+v", previous.Edges)
+	}
+}
+
+// Regression-style test: cursorIndex binary-searches by the ordering value
+// captured in the cursor, not a raw slice index, so a cursor issued before a
+// concurrent insert still resumes from the correct spot afterwards
+func TestPaginateCursorStableAcrossConcurrentInsert(t *testing.T) {
+	g := buildRankedGraph(t, 4)
+	q := NewQuery(g, "a", "b", "c", "d").OrderBy("rank", Asc)
+
+	page1, err := q.Paginate(2, "")
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	cursor := page1.PageInfo.EndCursor
+
+	// A concurrent insert lands between the nodes already paginated and the
+	// ones not yet seen, then the query is refreshed to pick it up
+	inserted, err := g.MergeNode("x", map[string]interface{}{"rank": 1.5})
+	if err != nil {
+		t.Fatalf("MergeNode: %v", err)
+	}
+	q.result[inserted.Key] = inserted
+	q.OrderBy("rank", Asc)
+
+	page2, err := q.Paginate(10, cursor)
+	if err != nil {
+		t.Fatalf("Paginate after cursor: %v", err)
+	}
+	if len(page2.Edges) != 2 || page2.Edges[0].Node.Key != "c" || page2.Edges[1].Node.Key != "d" {
+		t.Fatalf("expected the cursor to resume after rank 2 and skip the new lower-ranked insert, got %+v", page2.Edges)
+	}
+}
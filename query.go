@@ -1,5 +1,7 @@
 package graphgo
 
+import "path"
+
 // Query on top of a Graph instance
 // Aims to have "functional" style
 type Query struct {
@@ -9,6 +11,13 @@ type Query struct {
 
 	Key     string
 	Queries map[string]*Query
+
+	// orderKey, orderDir and ordered back OrderBy/Paginate: ordered holds the
+	// current result's node keys sorted by orderKey, materialized once so
+	// Paginate/PaginateLast can binary-search it repeatedly
+	orderKey string
+	orderDir SortDir
+	ordered  []string
 }
 
 // NewEmptyQuery instanciates
@@ -36,7 +45,7 @@ func NewQuery(g *Graph, starts ...string) *Query {
 		result[node.Key] = node
 	}
 
-	return &Query{
+	q := &Query{
 		Graph:  g,
 		result: result,
 		Cache:  map[string]interface{}{},
@@ -45,6 +54,42 @@ func NewQuery(g *Graph, starts ...string) *Query {
 		Queries: map[string]*Query{},
 	}
 
+	if g != nil {
+		g.registerQuery(q)
+	}
+
+	return q
+}
+
+// prune drops any node from the result set that the underlying graph no
+// longer holds. Called automatically whenever the graph mutates, so a
+// Query never hands back a dangling *Node after a delete
+func (q *Query) prune() {
+	if q.IsDeep() {
+		for _, nestedQuery := range q.Queries {
+			nestedQuery.prune()
+		}
+		return
+	}
+
+	for key := range q.result {
+		if !q.Graph.HasNode(key) {
+			delete(q.result, key)
+		}
+	}
+
+	// q.ordered (materialized by OrderBy) holds a separate snapshot of keys
+	// and must be pruned too, or Paginate/buildPage would keep indexing into
+	// it and dereference a key that's no longer in q.result
+	if q.ordered != nil {
+		kept := q.ordered[:0]
+		for _, key := range q.ordered {
+			if _, ok := q.result[key]; ok {
+				kept = append(kept, key)
+			}
+		}
+		q.ordered = kept
+	}
 }
 
 // IsDeep checks if this is a nested query
@@ -58,6 +103,14 @@ func (q *Query) IsDeep() bool {
 	return true
 }
 
+// labelMatches reports whether edgeLabel satisfies the label selector
+// pattern passed to Out/In. pattern is matched via path.Match, so it
+// supports glob wildcards (*, **, ?) as well as plain equality
+func labelMatches(pattern, edgeLabel string) bool {
+	matched, err := path.Match(pattern, edgeLabel)
+	return err == nil && matched
+}
+
 // IsDoubleDeep returns true if depth >= 2
 func (q *Query) IsDoubleDeep() bool {
 	if !q.IsDeep() {
@@ -91,7 +144,7 @@ func (q *Query) Out(label string) *Query {
 		for edgeKey, edgeLabel := range node.Out {
 
 			// Only keep the ones with given label
-			if edgeLabel == label {
+			if labelMatches(label, edgeLabel) {
 
 				edge, err := q.Graph.GetEdge(edgeKey)
 				if err != nil {
@@ -136,7 +189,7 @@ func (q *Query) In(label string) *Query {
 		for edgeKey, edgeLabel := range node.In {
 
 			// Only keep the ones with given label
-			if edgeLabel == label {
+			if labelMatches(label, edgeLabel) {
 
 				edge, err := q.Graph.GetEdge(edgeKey)
 				if err != nil {
@@ -327,6 +380,231 @@ func (q *Query) Flatten(saveCache bool) *Query {
 
 }
 
+// Expand accumulates every node reachable from the current result within
+// maxDepth hops, following edges whose label is in labels (any label if none
+// are given). The result replaces the current one and includes the starting
+// nodes themselves
+func (q *Query) Expand(maxDepth int, labels ...string) *Query {
+	// Deep Calls
+	if q.IsDeep() {
+		for _, nestedQuery := range q.Queries {
+			nestedQuery.Expand(maxDepth, labels...)
+		}
+		return q
+	}
+
+	labelSet := map[string]bool{}
+	for _, label := range labels {
+		labelSet[label] = true
+	}
+
+	visited := map[string]*Node{}
+	frontier := make([]string, 0, len(q.result))
+	for key, node := range q.result {
+		visited[key] = node
+		frontier = append(frontier, key)
+	}
+
+	for depth := 0; depth < maxDepth && len(frontier) > 0; depth++ {
+		next := []string{}
+
+		for _, key := range frontier {
+			node, err := q.Graph.getNode(key)
+			if err != nil {
+				continue
+			}
+
+			for edgeKey, label := range node.Out {
+				if len(labelSet) > 0 && !labelSet[label] {
+					continue
+				}
+
+				edge, err := q.Graph.GetEdge(edgeKey)
+				if err != nil {
+					continue
+				}
+
+				endNode, err := q.Graph.GetNode(edge.End)
+				if err != nil {
+					continue
+				}
+
+				if _, ok := visited[endNode.Key]; ok {
+					continue
+				}
+
+				visited[endNode.Key] = endNode
+				next = append(next, endNode.Key)
+			}
+		}
+
+		frontier = next
+	}
+
+	q.result = visited
+	return q
+}
+
+// Path narrows the result to the nodes lying on a shortest path from each
+// node currently in the result to endKey
+func (q *Query) Path(endKey string) *Query {
+	// Deep Calls
+	if q.IsDeep() {
+		for _, nestedQuery := range q.Queries {
+			nestedQuery.Path(endKey)
+		}
+		return q
+	}
+
+	newResult := map[string]*Node{}
+
+	for key := range q.result {
+		edges, _, err := q.Graph.ShortestPath(key, endKey, nil)
+		if err != nil {
+			continue
+		}
+
+		for _, edge := range edges {
+			if startNode, err := q.Graph.GetNode(edge.Start); err == nil {
+				newResult[startNode.Key] = startNode
+			}
+			if endNode, err := q.Graph.GetNode(edge.End); err == nil {
+				newResult[endNode.Key] = endNode
+			}
+		}
+	}
+
+	q.result = newResult
+	return q
+}
+
+// TopoOrder returns the keys currently in the result, ordered topologically
+// according to the underlying graph's dependency edges. Nodes outside the
+// result are dropped but their edges still count towards the ordering
+func (q *Query) TopoOrder() []string {
+	order, err := q.Graph.TopologicalSort()
+	if err != nil {
+		return nil
+	}
+
+	filtered := make([]string, 0, len(q.result))
+	for _, key := range order {
+		if _, ok := q.result[key]; ok {
+			filtered = append(filtered, key)
+		}
+	}
+
+	return filtered
+}
+
+// Group partitions the current result into separate queries sharing a
+// common value of by(node) - the classic "auto-grouping" pattern, letting
+// callers process results in batches that share an attribute
+func (q *Query) Group(by func(*Node) string) map[string]*Query {
+	groups := map[string]*Query{}
+
+	for _, node := range q.result {
+		key := by(node)
+
+		group, ok := groups[key]
+		if !ok {
+			group = NewEmptyQuery()
+			group.Graph = q.Graph
+			if q.Graph != nil {
+				q.Graph.registerQuery(group)
+			}
+			groups[key] = group
+		}
+
+		group.result[node.Key] = node
+	}
+
+	return groups
+}
+
+// Empty reports whether the current result set holds no nodes
+func (q *Query) Empty() bool {
+	if q.IsDeep() {
+		for _, nestedQuery := range q.Queries {
+			if !nestedQuery.Empty() {
+				return false
+			}
+		}
+		return true
+	}
+	return len(q.result) == 0
+}
+
+// Clone returns an independent copy of q, sharing the same underlying graph
+// and result nodes, so a caller can branch into alternative sub-queries
+// without mutating the original
+func (q *Query) Clone() *Query {
+	result := map[string]*Node{}
+	for k, v := range q.result {
+		result[k] = v
+	}
+	cache := map[string]interface{}{}
+	for k, v := range q.Cache {
+		cache[k] = v
+	}
+
+	clone := &Query{
+		Graph:  q.Graph,
+		result: result,
+		Cache:  cache,
+
+		Key:     q.Key,
+		Queries: map[string]*Query{},
+	}
+	for k, nestedQuery := range q.Queries {
+		clone.Queries[k] = nestedQuery.Clone()
+	}
+
+	if q.Graph != nil {
+		q.Graph.registerQuery(clone)
+	}
+
+	return clone
+}
+
+// Union merges another query's result set into this one
+func (q *Query) Union(other *Query) *Query {
+	if q.IsDeep() {
+		for key, nestedQuery := range q.Queries {
+			if otherNested, ok := other.Queries[key]; ok {
+				nestedQuery.Union(otherNested)
+			}
+		}
+		return q
+	}
+
+	for key, node := range other.result {
+		q.result[key] = node
+	}
+	return q
+}
+
+// Intersect keeps only the nodes present in both this query and other
+func (q *Query) Intersect(other *Query) *Query {
+	if q.IsDeep() {
+		for key, nestedQuery := range q.Queries {
+			if otherNested, ok := other.Queries[key]; ok {
+				nestedQuery.Intersect(otherNested)
+			}
+		}
+		return q
+	}
+
+	newResult := map[string]*Node{}
+	for key, node := range q.result {
+		if _, ok := other.result[key]; ok {
+			newResult[key] = node
+		}
+	}
+	q.result = newResult
+	return q
+}
+
 // DeepFilter
 func (q *Query) DeepFilter(keepQuery func(*Query) bool) *Query {
 
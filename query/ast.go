@@ -0,0 +1,62 @@
+package query
+
+// Expr is a single node of a compiled query expression tree
+type Expr interface {
+	isExpr()
+}
+
+// OutExpr follows outgoing edges carrying Label
+type OutExpr struct {
+	Label string
+}
+
+// InExpr follows incoming edges carrying Label
+type InExpr struct {
+	Label string
+}
+
+// FilterExpr keeps the nodes whose Key property satisfies Op against Value.
+// Op is one of "~" (regular expression match), "<", "<=", ">", ">=", "==", "!="
+type FilterExpr struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// GetExpr flattens Keys off every node in the result, cached under Name
+type GetExpr struct {
+	Name string
+	Keys []string
+}
+
+// DeepenExpr turns the current result into a per-node nested query under Key
+type DeepenExpr struct {
+	Key string
+}
+
+// PipeExpr evaluates Left, then feeds its result into Right
+type PipeExpr struct {
+	Left  Expr
+	Right Expr
+}
+
+// UnionExpr evaluates every sub-expression independently from the same
+// starting point and merges their results
+type UnionExpr struct {
+	Exprs []Expr
+}
+
+// IntersectExpr evaluates every sub-expression independently from the same
+// starting point and keeps only the nodes common to all of them
+type IntersectExpr struct {
+	Exprs []Expr
+}
+
+func (*OutExpr) isExpr()       {}
+func (*InExpr) isExpr()        {}
+func (*FilterExpr) isExpr()    {}
+func (*GetExpr) isExpr()       {}
+func (*DeepenExpr) isExpr()    {}
+func (*PipeExpr) isExpr()      {}
+func (*UnionExpr) isExpr()     {}
+func (*IntersectExpr) isExpr() {}
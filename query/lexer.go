@@ -0,0 +1,116 @@
+package query
+
+import "fmt"
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokArrow
+	tokColon
+	tokComma
+	tokLParen
+	tokRParen
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lex tokenizes a query source string, e.g.
+// `out:knows -> filter(name~"^A.*") -> in:follows -> deepen("friends")`
+func lex(src string) ([]token, error) {
+	var toks []token
+	runes := []rune(src)
+	i, n := 0, len(runes)
+
+	for i < n {
+		c := runes[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '-' && i+1 < n && runes[i+1] == '>':
+			toks = append(toks, token{tokArrow, "->"})
+			i += 2
+
+		case c == ':':
+			toks = append(toks, token{tokColon, ":"})
+			i++
+
+		case c == ',':
+			toks = append(toks, token{tokComma, ","})
+			i++
+
+		case c == '(':
+			toks = append(toks, token{tokLParen, "("})
+			i++
+
+		case c == ')':
+			toks = append(toks, token{tokRParen, ")"})
+			i++
+
+		case c == '"':
+			j := i + 1
+			for j < n && runes[j] != '"' {
+				j++
+			}
+			if j >= n {
+				return nil, fmt.Errorf("query: unterminated string starting at position %d", i)
+			}
+			toks = append(toks, token{tokString, string(runes[i+1 : j])})
+			i = j + 1
+
+		case c == '~' || c == '<' || c == '>' || c == '=' || c == '!':
+			op := string(c)
+			i++
+			if i < n && runes[i] == '=' && op != "~" {
+				op += "="
+				i++
+			}
+			if op == "=" {
+				return nil, fmt.Errorf("query: unexpected operator %q, did you mean \"==\"?", op)
+			}
+			toks = append(toks, token{tokOp, op})
+
+		case isDigitRune(c):
+			j := i
+			for j < n && (isDigitRune(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{tokNumber, string(runes[i:j])})
+			i = j
+
+		case isIdentRune(c):
+			j := i
+			for j < n && isIdentRune(runes[j]) {
+				j++
+			}
+			toks = append(toks, token{tokIdent, string(runes[i:j])})
+			i = j
+
+		default:
+			return nil, fmt.Errorf("query: unexpected character %q at position %d", c, i)
+		}
+	}
+
+	toks = append(toks, token{tokEOF, ""})
+	return toks, nil
+}
+
+// isIdentRune also accepts the glob wildcards (*, ?) and the path-ish
+// characters (., -) so labels like "knows*" or "org.unit-a" lex as one token
+func isIdentRune(c rune) bool {
+	return c == '_' || c == '*' || c == '?' || c == '.' || c == '-' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isDigitRune(c rune) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,250 @@
+package query
+
+import "fmt"
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// validFilterOps are the only operators filter() accepts; the lexer emits a
+// tokOp for any ~/</>/=/! run, so expectOp narrows that down to the seven this
+// language actually documents
+var validFilterOps = map[string]bool{
+	"~": true, "<": true, "<=": true, ">": true, ">=": true, "==": true, "!=": true,
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) atEnd() bool {
+	return p.peek().kind == tokEOF
+}
+
+func (p *parser) match(kind tokenKind) bool {
+	if p.peek().kind == kind {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind) error {
+	if !p.match(kind) {
+		return fmt.Errorf("query: unexpected token %q", p.peek().text)
+	}
+	return nil
+}
+
+func (p *parser) expectIdent() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokIdent {
+		return "", fmt.Errorf("query: expected identifier, got %q", tok.text)
+	}
+	p.advance()
+	return tok.text, nil
+}
+
+func (p *parser) expectString() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokString {
+		return "", fmt.Errorf("query: expected string literal, got %q", tok.text)
+	}
+	p.advance()
+	return tok.text, nil
+}
+
+func (p *parser) expectOp() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return "", fmt.Errorf("query: expected comparison operator, got %q", tok.text)
+	}
+	if !validFilterOps[tok.text] {
+		return "", fmt.Errorf("query: unknown operator %q, expected one of ~ < <= > >= == !=", tok.text)
+	}
+	p.advance()
+	return tok.text, nil
+}
+
+// expectValue accepts either a string or a numeric literal, since filter()
+// compares against both (e.g. name~"A*" and age>=18)
+func (p *parser) expectValue() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokString && tok.kind != tokNumber {
+		return "", fmt.Errorf("query: expected value, got %q", tok.text)
+	}
+	p.advance()
+	return tok.text, nil
+}
+
+// Compile parses src into a runnable Program
+func Compile(src string) (*Program, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: toks}
+	expr, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if !p.atEnd() {
+		return nil, fmt.Errorf("query: unexpected trailing token %q", p.peek().text)
+	}
+
+	return &Program{root: expr}, nil
+}
+
+// parsePipe parses a left-associative chain of `->` separated expressions
+func (p *parser) parsePipe() (Expr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(tokArrow) {
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = &PipeExpr{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.peek()
+	if tok.kind != tokIdent {
+		return nil, fmt.Errorf("query: expected expression, got %q", tok.text)
+	}
+
+	switch tok.text {
+	case "out":
+		p.advance()
+		if err := p.expect(tokColon); err != nil {
+			return nil, err
+		}
+		label, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		return &OutExpr{Label: label}, nil
+
+	case "in":
+		p.advance()
+		if err := p.expect(tokColon); err != nil {
+			return nil, err
+		}
+		label, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		return &InExpr{Label: label}, nil
+
+	case "filter":
+		p.advance()
+		if err := p.expect(tokLParen); err != nil {
+			return nil, err
+		}
+		key, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		op, err := p.expectOp()
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.expectValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return &FilterExpr{Key: key, Op: op, Value: value}, nil
+
+	case "get":
+		p.advance()
+		if err := p.expect(tokLParen); err != nil {
+			return nil, err
+		}
+		name, err := p.expectString()
+		if err != nil {
+			return nil, err
+		}
+		keys := []string{}
+		for p.match(tokComma) {
+			key, err := p.expectString()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		if err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return &GetExpr{Name: name, Keys: keys}, nil
+
+	case "deepen":
+		p.advance()
+		if err := p.expect(tokLParen); err != nil {
+			return nil, err
+		}
+		key, err := p.expectString()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return &DeepenExpr{Key: key}, nil
+
+	case "union":
+		return p.parseCombinator(func(exprs []Expr) Expr { return &UnionExpr{Exprs: exprs} })
+
+	case "intersect":
+		return p.parseCombinator(func(exprs []Expr) Expr { return &IntersectExpr{Exprs: exprs} })
+	}
+
+	return nil, fmt.Errorf("query: unknown expression %q", tok.text)
+}
+
+// parseCombinator parses `name(expr, expr, ...)` for union()/intersect(),
+// where each argument is itself a full pipe expression
+func (p *parser) parseCombinator(build func([]Expr) Expr) (Expr, error) {
+	p.advance()
+	if err := p.expect(tokLParen); err != nil {
+		return nil, err
+	}
+
+	first, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []Expr{first}
+
+	for p.match(tokComma) {
+		next, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, next)
+	}
+
+	if err := p.expect(tokRParen); err != nil {
+		return nil, err
+	}
+
+	return build(exprs), nil
+}
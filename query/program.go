@@ -0,0 +1,150 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/tantanchen/graphgo"
+)
+
+// Program is a compiled query expression that can be run against any Graph
+// without recompiling, letting callers configure traversals from config
+// files or HTTP requests
+type Program struct {
+	root Expr
+}
+
+// Run evaluates the compiled program against graph, starting from starts
+func (p *Program) Run(g *graphgo.Graph, starts ...string) *graphgo.Query {
+	return eval(graphgo.NewQuery(g, starts...), p.root)
+}
+
+func eval(q *graphgo.Query, expr Expr) *graphgo.Query {
+	switch e := expr.(type) {
+	case *OutExpr:
+		return q.Out(e.Label)
+
+	case *InExpr:
+		return q.In(e.Label)
+
+	case *FilterExpr:
+		return q.FilterNodes(func(props map[string]interface{}) bool {
+			value, ok := props[e.Key]
+			if !ok {
+				return false
+			}
+			return evalFilter(value, e.Op, e.Value)
+		})
+
+	case *GetExpr:
+		return q.Get(e.Name, e.Keys...)
+
+	case *DeepenExpr:
+		return q.Deepen(e.Key)
+
+	case *PipeExpr:
+		left := eval(q, e.Left)
+		// Short-circuit: an empty result set can't grow by piping it further,
+		// so skip evaluating the right-hand side altogether
+		if left.Empty() {
+			return left
+		}
+		return eval(left, e.Right)
+
+	case *UnionExpr:
+		return evalCombinator(q, e.Exprs, (*graphgo.Query).Union)
+
+	case *IntersectExpr:
+		return evalCombinator(q, e.Exprs, (*graphgo.Query).Intersect)
+	}
+
+	panic(fmt.Sprintf("query: unhandled expression %T", expr))
+}
+
+// evalCombinator evaluates every sub-expression independently from a clone of
+// q, then folds the branches together with combine (Union or Intersect)
+func evalCombinator(q *graphgo.Query, exprs []Expr, combine func(*graphgo.Query, *graphgo.Query) *graphgo.Query) *graphgo.Query {
+	var acc *graphgo.Query
+
+	for _, sub := range exprs {
+		branch := eval(q.Clone(), sub)
+		if acc == nil {
+			acc = branch
+			continue
+		}
+		acc = combine(acc, branch)
+	}
+
+	return acc
+}
+
+// evalFilter applies a single filter comparison against a property value.
+// "~" matches a string property against want as a regular expression (e.g.
+// name~"^A.*"); the rest are numeric comparisons resolved via reflection,
+// falling back to string equality for "==" and "!=" on non-numeric properties
+func evalFilter(value interface{}, op, want string) bool {
+	switch op {
+	case "~":
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		matched, err := regexp.MatchString(want, s)
+		return err == nil && matched
+
+	case "==", "!=":
+		if s, ok := value.(string); ok {
+			eq := s == want
+			if op == "!=" {
+				return !eq
+			}
+			return eq
+		}
+		fallthrough
+
+	default:
+		return compareNumeric(value, op, want)
+	}
+}
+
+func compareNumeric(value interface{}, op, want string) bool {
+	got, ok := toFloat(value)
+	if !ok {
+		return false
+	}
+	wantFloat, err := strconv.ParseFloat(want, 64)
+	if err != nil {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return got < wantFloat
+	case "<=":
+		return got <= wantFloat
+	case ">":
+		return got > wantFloat
+	case ">=":
+		return got >= wantFloat
+	case "==":
+		return got == wantFloat
+	case "!=":
+		return got != wantFloat
+	}
+	return false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
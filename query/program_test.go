@@ -0,0 +1,123 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/tantanchen/graphgo"
+	"github.com/tantanchen/graphgo/query"
+)
+
+func TestOutGlobLabelSelector(t *testing.T) {
+	g := graphgo.NewEmptyGraph()
+	g.MergeNode("alice", nil)
+	g.MergeNode("bob", nil)
+	if _, err := g.MergeEdge("e1", "knows-bob", "alice", "bob", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	prog, err := query.Compile(`out:knows*`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	result := prog.Run(g, "alice").Get("matched")
+	matched, ok := result.Cache["matched"].(map[string]map[string]interface{})
+	if !ok || len(matched) != 1 {
+		t.Fatalf("expected out:knows* to match the edge labeled knows-bob, got %#v", result.Cache["matched"])
+	}
+	if _, ok := matched["bob"]; !ok {
+		t.Fatalf("expected bob in the matched set, got %#v", matched)
+	}
+}
+
+func TestOutExactLabelStillMatches(t *testing.T) {
+	g := graphgo.NewEmptyGraph()
+	g.MergeNode("alice", nil)
+	g.MergeNode("bob", nil)
+	g.MergeNode("carl", nil)
+	if _, err := g.MergeEdge("e1", "knows", "alice", "bob", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e2", "hates", "alice", "carl", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	prog, err := query.Compile(`out:knows`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	result := prog.Run(g, "alice").Get("matched")
+	matched := result.Cache["matched"].(map[string]map[string]interface{})
+	if len(matched) != 1 {
+		t.Fatalf("expected exact label to only match bob, got %#v", matched)
+	}
+	if _, ok := matched["carl"]; ok {
+		t.Fatalf("exact label out:knows should not match edge labeled hates")
+	}
+}
+
+// Regression test: Query.Clone (used by union()/intersect()) must register
+// its clone with the graph, or results stop being pruned once the
+// underlying nodes are deleted
+func TestUnionResultIsPrunedAfterDelete(t *testing.T) {
+	g := graphgo.NewEmptyGraph()
+	g.MergeNode("alice", nil)
+	g.MergeNode("carl", nil)
+	g.MergeNode("dave", nil)
+	if _, err := g.MergeEdge("e1", "knows", "alice", "carl", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e2", "knows", "alice", "dave", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	prog, err := query.Compile(`union(out:knows, out:knows)`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	result := prog.Run(g, "alice")
+	if result.Empty() {
+		t.Fatalf("expected union result to include carl and dave")
+	}
+
+	if err := g.DeleteNode("carl"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if err := g.DeleteNode("dave"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+
+	if !result.Empty() {
+		t.Fatalf("expected union result to be pruned once its nodes were deleted")
+	}
+}
+
+// Regression test: "~" must match as a regular expression, per the
+// language's own canonical example (filter(name~"^A.*")), not as a glob
+func TestFilterRegexMatch(t *testing.T) {
+	g := graphgo.NewEmptyGraph()
+	g.MergeNode("alice", map[string]interface{}{"name": "Alice"})
+	g.MergeNode("bob", map[string]interface{}{"name": "Bob"})
+
+	prog, err := query.Compile(`filter(name~"^A.*")`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	result := prog.Run(g, "alice", "bob").Get("matched")
+	matched := result.Cache["matched"].(map[string]map[string]interface{})
+	if len(matched) != 1 {
+		t.Fatalf("expected filter(name~\"^A.*\") to match only Alice, got %#v", matched)
+	}
+	if _, ok := matched["alice"]; !ok {
+		t.Fatalf("expected alice in the matched set, got %#v", matched)
+	}
+}
+
+func TestFilterRejectsUnknownOperator(t *testing.T) {
+	if _, err := query.Compile(`filter(age!5)`); err == nil {
+		t.Fatalf("expected Compile to reject the non-operator %q", "!5")
+	}
+}
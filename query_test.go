@@ -0,0 +1,84 @@
+package graphgo
+
+import "testing"
+
+func buildChainGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	g.MergeNode("c", nil)
+	g.MergeNode("d", nil)
+	if _, err := g.MergeEdge("ab", "knows", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("bc", "knows", "b", "c", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("cd", "hates", "c", "d", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	return g
+}
+
+func TestQueryExpandWalksUpToMaxDepth(t *testing.T) {
+	g := buildChainGraph(t)
+
+	q := NewQuery(g, "a").Expand(2)
+	if q.Empty() {
+		t.Fatalf("expected Expand to reach at least one node")
+	}
+	if _, ok := q.result["a"]; !ok {
+		t.Fatalf("expected Expand to keep the starting node a, got %v", q.result)
+	}
+	if _, ok := q.result["b"]; !ok {
+		t.Fatalf("expected Expand(2) to reach b, got %v", q.result)
+	}
+	if _, ok := q.result["c"]; !ok {
+		t.Fatalf("expected Expand(2) to reach c, got %v", q.result)
+	}
+	if _, ok := q.result["d"]; ok {
+		t.Fatalf("expected Expand(2) to NOT reach d (3 hops away), got %v", q.result)
+	}
+}
+
+func TestQueryExpandFiltersByLabel(t *testing.T) {
+	g := buildChainGraph(t)
+
+	q := NewQuery(g, "a").Expand(3, "knows")
+	if _, ok := q.result["c"]; !ok {
+		t.Fatalf("expected Expand(3, \"knows\") to reach c via knows edges, got %v", q.result)
+	}
+	if _, ok := q.result["d"]; ok {
+		t.Fatalf("expected Expand(3, \"knows\") to NOT cross the hates edge to d, got %v", q.result)
+	}
+}
+
+func TestQueryPathNarrowsToShortestPathNodes(t *testing.T) {
+	g := buildChainGraph(t)
+
+	q := NewQuery(g, "a").Path("c")
+	if _, ok := q.result["a"]; !ok {
+		t.Fatalf("expected Path to keep a, got %v", q.result)
+	}
+	if _, ok := q.result["b"]; !ok {
+		t.Fatalf("expected Path to include b, which lies on the shortest path to c, got %v", q.result)
+	}
+	if _, ok := q.result["c"]; !ok {
+		t.Fatalf("expected Path to keep the target node c, got %v", q.result)
+	}
+	if _, ok := q.result["d"]; ok {
+		t.Fatalf("expected Path(\"c\") to exclude d, which isn't on the path to c, got %v", q.result)
+	}
+}
+
+func TestQueryPathDropsNodesWithNoRoute(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+
+	q := NewQuery(g, "a").Path("b")
+	if !q.Empty() {
+		t.Fatalf("expected Path to an unreachable node to leave the result empty, got %v", q.result)
+	}
+}
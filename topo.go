@@ -0,0 +1,187 @@
+package graphgo
+
+import "sort"
+
+// TopologicalSort returns node keys in dependency order using Kahn's
+// algorithm: in-degrees are computed from node.In, a queue is seeded with
+// every zero in-degree node, and each pop decrements its successors'
+// in-degrees via node.Out. If not every node is consumed, the graph has a
+// cycle and the error lists the nodes forming it (see cycleNodes); nodes
+// merely downstream of the cycle are left out
+func (graph *Graph) TopologicalSort() ([]string, error) {
+	inDegree := map[string]int{}
+	for key, node := range graph.Nodes {
+		inDegree[key] = len(node.In)
+	}
+
+	queue := []string{}
+	for key, degree := range inDegree {
+		if degree == 0 {
+			queue = append(queue, key)
+		}
+	}
+
+	order := make([]string, 0, len(graph.Nodes))
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		order = append(order, key)
+
+		node, err := graph.getNode(key)
+		if err != nil {
+			continue
+		}
+
+		for edgeKey := range node.Out {
+			edge, err := graph.getEdge(edgeKey)
+			if err != nil {
+				continue
+			}
+			inDegree[edge.End]--
+			if inDegree[edge.End] == 0 {
+				queue = append(queue, edge.End)
+			}
+		}
+	}
+
+	if len(order) != len(graph.Nodes) {
+		unresolved := map[string]bool{}
+		for key, degree := range inDegree {
+			if degree > 0 {
+				unresolved[key] = true
+			}
+		}
+		return nil, errCycleDetected(graph.cycleNodes(unresolved))
+	}
+
+	return order, nil
+}
+
+// cycleNodes narrows candidates (the nodes Kahn's algorithm couldn't
+// resolve) down to only those actually forming a cycle, using the strongly
+// connected components returned by a fresh StronglyConnectedComponents call: a
+// node merely downstream of a cycle sits in its own singleton component and
+// is excluded, while a node in a multi-node component, or a singleton with a
+// self-loop, is part of the cycle
+func (graph *Graph) cycleNodes(candidates map[string]bool) []string {
+	keys := map[string]bool{}
+
+	for _, component := range graph.StronglyConnectedComponents() {
+		if len(component) > 1 {
+			for _, key := range component {
+				if candidates[key] {
+					keys[key] = true
+				}
+			}
+			continue
+		}
+
+		key := component[0]
+		if candidates[key] && graph.nodeHasSelfLoop(key) {
+			keys[key] = true
+		}
+	}
+
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// nodeHasSelfLoop reports whether key has an edge looping back to itself
+func (graph *Graph) nodeHasSelfLoop(key string) bool {
+	node, err := graph.getNode(key)
+	if err != nil {
+		return false
+	}
+	for edgeKey := range node.Out {
+		edge, err := graph.getEdge(edgeKey)
+		if err == nil && edge.Start == edge.End {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCycle reports whether the graph contains any directed cycle
+func (graph *Graph) HasCycle() bool {
+	_, err := graph.TopologicalSort()
+	return err != nil
+}
+
+// tarjanState carries the bookkeeping Tarjan's algorithm needs across its
+// recursive strong-connect calls
+type tarjanState struct {
+	index   int
+	indices map[string]int
+	lowlink map[string]int
+	onStack map[string]bool
+	stack   []string
+	result  [][]string
+}
+
+// StronglyConnectedComponents returns every strongly connected component of
+// the graph, computed via Tarjan's algorithm. A node with no cycle through it
+// forms its own singleton component
+func (graph *Graph) StronglyConnectedComponents() [][]string {
+	state := &tarjanState{
+		indices: map[string]int{},
+		lowlink: map[string]int{},
+		onStack: map[string]bool{},
+	}
+
+	for key := range graph.Nodes {
+		if _, visited := state.indices[key]; !visited {
+			graph.tarjanStrongConnect(key, state)
+		}
+	}
+
+	return state.result
+}
+
+func (graph *Graph) tarjanStrongConnect(key string, state *tarjanState) {
+	state.indices[key] = state.index
+	state.lowlink[key] = state.index
+	state.index++
+	state.stack = append(state.stack, key)
+	state.onStack[key] = true
+
+	node, err := graph.getNode(key)
+	if err == nil {
+		for edgeKey := range node.Out {
+			edge, err := graph.getEdge(edgeKey)
+			if err != nil {
+				continue
+			}
+
+			successor := edge.End
+			if _, visited := state.indices[successor]; !visited {
+				graph.tarjanStrongConnect(successor, state)
+				if state.lowlink[successor] < state.lowlink[key] {
+					state.lowlink[key] = state.lowlink[successor]
+				}
+			} else if state.onStack[successor] {
+				if state.indices[successor] < state.lowlink[key] {
+					state.lowlink[key] = state.indices[successor]
+				}
+			}
+		}
+	}
+
+	if state.lowlink[key] == state.indices[key] {
+		component := []string{}
+		for {
+			n := len(state.stack) - 1
+			top := state.stack[n]
+			state.stack = state.stack[:n]
+			state.onStack[top] = false
+			component = append(component, top)
+			if top == key {
+				break
+			}
+		}
+		state.result = append(state.result, component)
+	}
+}
@@ -0,0 +1,165 @@
+package graphgo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTopologicalSortOrdersDependencies(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	g.MergeNode("c", nil)
+	if _, err := g.MergeEdge("e1", "precedes", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e2", "precedes", "b", "c", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort: %v", err)
+	}
+
+	pos := map[string]int{}
+	for i, key := range order {
+		pos[key] = i
+	}
+	if pos["a"] > pos["b"] || pos["b"] > pos["c"] {
+		t.Fatalf("expected order a, b, c; got %v", order)
+	}
+}
+
+func TestTopologicalSortDetectsCycle(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	if _, err := g.MergeEdge("e1", "next", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e2", "next", "b", "a", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	_, err := g.TopologicalSort()
+	if err == nil {
+		t.Fatalf("expected an error for a cyclic graph")
+	}
+	if !strings.Contains(err.Error(), "[a b]") {
+		t.Fatalf("expected the cycle error to name a and b, got %q", err.Error())
+	}
+	if !g.HasCycle() {
+		t.Fatalf("expected HasCycle to report true")
+	}
+}
+
+// Regression test: the cycle error must list only the nodes that actually
+// form the cycle, not nodes merely downstream of one
+func TestTopologicalSortCycleErrorExcludesDownstreamNodes(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	g.MergeNode("downstream", nil)
+	if _, err := g.MergeEdge("e1", "next", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e2", "next", "b", "a", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e3", "next", "b", "downstream", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	_, err := g.TopologicalSort()
+	if err == nil {
+		t.Fatalf("expected an error for a cyclic graph")
+	}
+	if strings.Contains(err.Error(), "downstream") {
+		t.Fatalf("expected cycle error to exclude downstream, got %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "[a b]") {
+		t.Fatalf("expected cycle error to name a and b, got %q", err.Error())
+	}
+}
+
+func TestTopologicalSortCycleErrorIncludesSelfLoop(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	if _, err := g.MergeEdge("e1", "next", "a", "a", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e2", "next", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	_, err := g.TopologicalSort()
+	if err == nil {
+		t.Fatalf("expected an error for a self-looping graph")
+	}
+	if !strings.Contains(err.Error(), "[a]") {
+		t.Fatalf("expected cycle error to name the self-looping node a, got %q", err.Error())
+	}
+	if strings.Contains(err.Error(), "b") {
+		t.Fatalf("expected cycle error to exclude b, which only depends on the cycle, got %q", err.Error())
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	g.MergeNode("c", nil)
+	if _, err := g.MergeEdge("e1", "next", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e2", "next", "b", "a", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("e3", "next", "b", "c", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	components := g.StronglyConnectedComponents()
+
+	var cycle []string
+	for _, component := range components {
+		if len(component) == 2 {
+			cycle = component
+		}
+	}
+	if cycle == nil {
+		t.Fatalf("expected a, b in the same strongly connected component, got %v", components)
+	}
+}
+
+// Regression test: Query.Group must register each group's Query with the
+// graph, or groups stop being pruned once their nodes are deleted
+func TestQueryGroupIsInvalidatedOnDelete(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", map[string]interface{}{"kind": "x"})
+	g.MergeNode("b", map[string]interface{}{"kind": "x"})
+
+	q := NewQuery(g, "a", "b")
+	groups := q.Group(func(node *Node) string {
+		value, _ := node.Get("kind")
+		return value.(string)
+	})
+
+	group := groups["x"]
+	if group.Empty() {
+		t.Fatalf("expected group \"x\" to contain a and b")
+	}
+
+	if err := g.DeleteNode("a"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if err := g.DeleteNode("b"); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+
+	if !group.Empty() {
+		t.Fatalf("expected group to be pruned after its nodes were deleted")
+	}
+}
@@ -0,0 +1,282 @@
+package graphgo
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+)
+
+// VisitOrder selects how VisitEdges walks edges reachable from its start node
+type VisitOrder int
+
+const (
+	// BFS visits edges breadth-first
+	BFS VisitOrder = iota
+	// DFSPreOrder visits an edge before descending into its end node's own edges
+	DFSPreOrder
+	// DFSPostOrder visits an edge after descending into its end node's own edges
+	DFSPostOrder
+)
+
+// VisitEdges walks the graph starting at start, calling fn on every edge
+// reached, in the order given by order. A visited set keyed by node key
+// guards against cycles. Equivalent to VisitEdgesContext(context.Background(), ...)
+func (graph *Graph) VisitEdges(start string, order VisitOrder, fn func(*Edge) error) error {
+	return graph.VisitEdgesContext(context.Background(), start, order, fn)
+}
+
+// VisitEdgesContext is VisitEdges with a context.Context: ctx is checked
+// before each node's outgoing edges are visited, so a long walk over a large
+// graph can be cancelled by the caller
+func (graph *Graph) VisitEdgesContext(ctx context.Context, start string, order VisitOrder, fn func(*Edge) error) error {
+	if !graph.HasNode(start) {
+		return errNodeNotFound(start)
+	}
+
+	switch order {
+	case BFS:
+		return graph.visitBFS(ctx, start, fn)
+	case DFSPreOrder:
+		return graph.visitDFS(ctx, start, map[string]bool{start: true}, fn, false)
+	case DFSPostOrder:
+		return graph.visitDFS(ctx, start, map[string]bool{start: true}, fn, true)
+	default:
+		return fmt.Errorf("graphgo: unknown visit order %v", order)
+	}
+}
+
+func (graph *Graph) visitBFS(ctx context.Context, start string, fn func(*Edge) error) error {
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		key := queue[0]
+		queue = queue[1:]
+
+		node, err := graph.getNode(key)
+		if err != nil {
+			continue
+		}
+
+		for edgeKey := range node.Out {
+			edge, err := graph.getEdge(edgeKey)
+			if err != nil {
+				continue
+			}
+			if err := fn(edge); err != nil {
+				return err
+			}
+			if !visited[edge.End] {
+				visited[edge.End] = true
+				queue = append(queue, edge.End)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (graph *Graph) visitDFS(ctx context.Context, key string, visited map[string]bool, fn func(*Edge) error, postOrder bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	node, err := graph.getNode(key)
+	if err != nil {
+		return nil
+	}
+
+	for edgeKey := range node.Out {
+		edge, err := graph.getEdge(edgeKey)
+		if err != nil {
+			continue
+		}
+
+		if !postOrder {
+			if err := fn(edge); err != nil {
+				return err
+			}
+		}
+
+		if !visited[edge.End] {
+			visited[edge.End] = true
+			if err := graph.visitDFS(ctx, edge.End, visited, fn, postOrder); err != nil {
+				return err
+			}
+		}
+
+		if postOrder {
+			if err := fn(edge); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PathSearch returns the first path found from start to any node satisfying
+// isGoal, as an ordered slice of edges, and whether such a path exists. It
+// explores breadth-first, so among paths with equal availability it returns
+// the one with the fewest hops
+func (graph *Graph) PathSearch(start string, isGoal func(*Node) bool) ([]*Edge, bool) {
+	startNode, err := graph.getNode(start)
+	if err != nil {
+		return nil, false
+	}
+	if isGoal(startNode) {
+		return []*Edge{}, true
+	}
+
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+	cameFrom := map[string]*Edge{}
+
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		node, err := graph.getNode(key)
+		if err != nil {
+			continue
+		}
+
+		for edgeKey := range node.Out {
+			edge, err := graph.getEdge(edgeKey)
+			if err != nil {
+				continue
+			}
+			if visited[edge.End] {
+				continue
+			}
+			visited[edge.End] = true
+			cameFrom[edge.End] = edge
+
+			endNode, err := graph.getNode(edge.End)
+			if err != nil {
+				continue
+			}
+			if isGoal(endNode) {
+				return reconstructPath(cameFrom, edge.End), true
+			}
+
+			queue = append(queue, edge.End)
+		}
+	}
+
+	return nil, false
+}
+
+// reconstructPath walks cameFrom backwards from end to build the ordered
+// edge path a Dijkstra or BFS search found
+func reconstructPath(cameFrom map[string]*Edge, end string) []*Edge {
+	path := []*Edge{}
+	for key := end; ; {
+		edge, ok := cameFrom[key]
+		if !ok {
+			break
+		}
+		path = append([]*Edge{edge}, path...)
+		key = edge.Start
+	}
+	return path
+}
+
+// pqItem is a single entry in the Dijkstra priority queue
+type pqItem struct {
+	nodeKey string
+	dist    float64
+	index   int
+}
+
+// distPQ implements container/heap.Interface over pqItems, ordered by
+// cumulative distance
+type distPQ []*pqItem
+
+func (pq distPQ) Len() int { return len(pq) }
+
+func (pq distPQ) Less(i, j int) bool { return pq[i].dist < pq[j].dist }
+
+func (pq distPQ) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *distPQ) Push(x interface{}) {
+	item := x.(*pqItem)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+
+func (pq *distPQ) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*pq = old[:n-1]
+	return item
+}
+
+// ShortestPath returns the lowest-cost path from start to end using
+// Dijkstra's algorithm over a priority queue keyed on cumulative cost. If
+// weight is nil, every edge costs 1
+func (graph *Graph) ShortestPath(start, end string, weight func(*Edge) float64) ([]*Edge, float64, error) {
+	if !graph.HasNode(start) {
+		return nil, 0, errNodeNotFound(start)
+	}
+	if !graph.HasNode(end) {
+		return nil, 0, errNodeNotFound(end)
+	}
+	if weight == nil {
+		weight = func(*Edge) float64 { return 1 }
+	}
+
+	dist := map[string]float64{start: 0}
+	cameFrom := map[string]*Edge{}
+	visited := map[string]bool{}
+
+	pq := &distPQ{{nodeKey: start, dist: 0}}
+	heap.Init(pq)
+
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(*pqItem)
+		if visited[current.nodeKey] {
+			continue
+		}
+		visited[current.nodeKey] = true
+
+		if current.nodeKey == end {
+			return reconstructPath(cameFrom, end), dist[end], nil
+		}
+
+		node, err := graph.getNode(current.nodeKey)
+		if err != nil {
+			continue
+		}
+
+		for edgeKey := range node.Out {
+			edge, err := graph.getEdge(edgeKey)
+			if err != nil {
+				continue
+			}
+			if visited[edge.End] {
+				continue
+			}
+
+			newDist := dist[current.nodeKey] + weight(edge)
+			if existing, ok := dist[edge.End]; !ok || newDist < existing {
+				dist[edge.End] = newDist
+				cameFrom[edge.End] = edge
+				heap.Push(pq, &pqItem{nodeKey: edge.End, dist: newDist})
+			}
+		}
+	}
+
+	return nil, 0, fmt.Errorf("graphgo: no path found from %q to %q", start, end)
+}
@@ -0,0 +1,124 @@
+package graphgo
+
+import "testing"
+
+func buildLineGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	g.MergeNode("c", nil)
+	if _, err := g.MergeEdge("ab", "next", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("bc", "next", "b", "c", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	return g
+}
+
+func TestVisitEdgesBFSOrder(t *testing.T) {
+	g := buildLineGraph(t)
+
+	var seen []string
+	err := g.VisitEdges("a", BFS, func(edge *Edge) error {
+		seen = append(seen, edge.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("VisitEdges: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != "ab" || seen[1] != "bc" {
+		t.Fatalf("unexpected BFS order: %v", seen)
+	}
+}
+
+func TestVisitEdgesHandlesCycles(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	if _, err := g.MergeEdge("ab", "next", "a", "b", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("ba", "next", "b", "a", nil); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	var visits int
+	err := g.VisitEdges("a", DFSPreOrder, func(edge *Edge) error {
+		visits++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("VisitEdges: %v", err)
+	}
+	if visits != 2 {
+		t.Fatalf("expected the visited set to stop the walk after 2 edges on a 2-node cycle, got %d", visits)
+	}
+}
+
+func TestPathSearchFindsShortestHopPath(t *testing.T) {
+	g := buildLineGraph(t)
+
+	path, found := g.PathSearch("a", func(n *Node) bool { return n.Key == "c" })
+	if !found {
+		t.Fatalf("expected a path from a to c")
+	}
+	if len(path) != 2 || path[0].Key != "ab" || path[1].Key != "bc" {
+		t.Fatalf("unexpected path: %v", path)
+	}
+}
+
+func TestPathSearchNoPath(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+
+	_, found := g.PathSearch("a", func(n *Node) bool { return n.Key == "b" })
+	if found {
+		t.Fatalf("expected no path between disconnected nodes")
+	}
+}
+
+func TestShortestPathPrefersCheaperRoute(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+	g.MergeNode("c", nil)
+
+	if _, err := g.MergeEdge("direct", "next", "a", "c", map[string]interface{}{"cost": 10.0}); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("ab", "next", "a", "b", map[string]interface{}{"cost": 1.0}); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+	if _, err := g.MergeEdge("bc", "next", "b", "c", map[string]interface{}{"cost": 1.0}); err != nil {
+		t.Fatalf("MergeEdge: %v", err)
+	}
+
+	weight := func(e *Edge) float64 {
+		cost, _ := e.Get("cost")
+		return cost.(float64)
+	}
+
+	path, dist, err := g.ShortestPath("a", "c", weight)
+	if err != nil {
+		t.Fatalf("ShortestPath: %v", err)
+	}
+	if dist != 2 {
+		t.Fatalf("expected cost 2 via a->b->c, got %v", dist)
+	}
+	if len(path) != 2 || path[0].Key != "ab" || path[1].Key != "bc" {
+		t.Fatalf("unexpected path: %v", path)
+	}
+}
+
+func TestShortestPathNoPathReturnsError(t *testing.T) {
+	g := NewEmptyGraph()
+	g.MergeNode("a", nil)
+	g.MergeNode("b", nil)
+
+	if _, _, err := g.ShortestPath("a", "b", nil); err == nil {
+		t.Fatalf("expected an error when no path connects a and b")
+	}
+}